@@ -0,0 +1,176 @@
+package nada
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// streamAllocation holds the per-stream parameters needed to divide an
+// aggregate reference rate across the streams in a StreamGroup.
+type streamAllocation struct {
+	prio             float64
+	minRate, maxRate BitsPerSecond
+}
+
+// allocationCandidate pairs a stream's id with its allocation parameters
+// while it is still being considered during allocate's water-filling pass.
+type allocationCandidate struct {
+	id string
+	*streamAllocation
+}
+
+// StreamGroup coordinates a single aggregate Sender across multiple media
+// streams sharing one bottleneck, dividing the aggregate reference rate
+// r_ref among them by priority weight, analogous to how an SFU splits a
+// shared send budget across a participant's tracks.
+type StreamGroup struct {
+	lock sync.Mutex
+
+	sender *Sender
+
+	streams map[string]*streamAllocation
+}
+
+// NewStreamGroup creates a StreamGroup whose aggregate Sender starts at
+// config.RMIN, as NewSender does.
+func NewStreamGroup(now time.Time, config Config) *StreamGroup {
+	return &StreamGroup{
+		sender:  NewSender(now, config),
+		streams: map[string]*streamAllocation{},
+	}
+}
+
+// AddStream registers a stream to participate in allocation, with a
+// priority weight prio and a clamping range [minRate, maxRate].
+func (g *StreamGroup) AddStream(id string, prio float64, minRate, maxRate BitsPerSecond) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.streams[id] = &streamAllocation{prio: prio, minRate: minRate, maxRate: maxRate}
+}
+
+// RemoveStream unregisters a stream previously added with AddStream.
+func (g *StreamGroup) RemoveStream(id string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	delete(g.streams, id)
+}
+
+// OnReceiveFeedbackReport updates the aggregate reference rate from a
+// single FeedbackReport describing the whole group's shared bottleneck,
+// then returns the resulting per-stream allocation.
+func (g *StreamGroup) OnReceiveFeedbackReport(now time.Time, report *FeedbackReport) map[string]BitsPerSecond {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.sender.OnReceiveFeedbackReport(now, report)
+	return g.allocate()
+}
+
+// Allocate returns the current per-stream allocation without updating the
+// aggregate reference rate, e.g. after AddStream/RemoveStream changes the
+// set of participating streams.
+func (g *StreamGroup) Allocate() map[string]BitsPerSecond {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.allocate()
+}
+
+// allocate divides r_ref among the registered streams using weighted
+// max-min fairness: streams whose fair share would fall outside their
+// [minRate, maxRate] bound are clamped and removed from further
+// consideration, and the remaining rate is re-divided among the rest by
+// priority weight, until every remaining stream's share equalizes
+// r_i / prio_i subject to its bound.
+func (g *StreamGroup) allocate() map[string]BitsPerSecond {
+	result := make(map[string]BitsPerSecond, len(g.streams))
+
+	active := make([]allocationCandidate, 0, len(g.streams))
+	for id, s := range g.streams {
+		active = append(active, allocationCandidate{id: id, streamAllocation: s})
+	}
+	// Deterministic order, so equal-priority ties clamp in a stable order.
+	sort.Slice(active, func(i, j int) bool { return active[i].id < active[j].id })
+
+	remaining := g.sender.TargetRate()
+
+	// Oversubscribed: even handing out every stream's minRate would exceed
+	// the budget. There's no room to honor minimums at all in this case,
+	// so scale every stream's minRate down proportionally instead of
+	// handing out full minimums and overshooting r_ref.
+	var totalMin BitsPerSecond
+	for _, c := range active {
+		totalMin += c.minRate
+	}
+	if totalMin > remaining {
+		for _, c := range active {
+			if totalMin > 0 {
+				result[c.id] = BitsPerSecond(float64(remaining) * float64(c.minRate) / float64(totalMin))
+			} else {
+				result[c.id] = 0
+			}
+		}
+		return result
+	}
+
+	for len(active) > 0 {
+		var prioSum float64
+		for _, c := range active {
+			prioSum += c.prio
+		}
+		if prioSum <= 0 {
+			break
+		}
+
+		clamped := false
+		for _, c := range active {
+			fairShare := BitsPerSecond(float64(remaining) * c.prio / prioSum)
+			if fairShare < c.minRate {
+				result[c.id] = c.minRate
+				remaining -= c.minRate
+				active = removeCandidate(active, c.id)
+				clamped = true
+				break
+			}
+			if fairShare > c.maxRate {
+				result[c.id] = c.maxRate
+				remaining -= c.maxRate
+				active = removeCandidate(active, c.id)
+				clamped = true
+				break
+			}
+		}
+		if clamped {
+			continue
+		}
+
+		for _, c := range active {
+			result[c.id] = BitsPerSecond(float64(remaining) * c.prio / prioSum)
+		}
+		break
+	}
+
+	return result
+}
+
+func removeCandidate(active []allocationCandidate, id string) []allocationCandidate {
+	for i, c := range active {
+		if c.id == id {
+			return append(active[:i], active[i+1:]...)
+		}
+	}
+	return active
+}
+
+// RecommendLayer returns the index into layerBitrates of the highest SVC
+// (or simulcast) layer whose bitrate does not exceed rate, given layer
+// bitrates in ascending order. It returns 0 if no layer fits, on the
+// assumption that layer 0 is always encoded.
+func RecommendLayer(rate BitsPerSecond, layerBitrates []BitsPerSecond) int {
+	best := 0
+	for i, bitrate := range layerBitrates {
+		if bitrate <= rate {
+			best = i
+		}
+	}
+	return best
+}
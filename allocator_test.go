@@ -0,0 +1,96 @@
+package nada
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestStreamGroupAllocatesByPriority(t *testing.T) {
+	now := time.Now()
+	config := DefaultConfig()
+	config.RMIN = 900_000
+	config.RMAX = 900_000
+
+	g := NewStreamGroup(now, config)
+	g.AddStream("video", 3, 0, config.RMAX)
+	g.AddStream("audio", 1, 0, config.RMAX)
+
+	alloc := g.OnReceiveFeedbackReport(now, &FeedbackReport{
+		RecommendedRateAdaptionMode: RateAdaptionModeAcceleratedRampUp,
+		ReceivingRate:               config.RMAX,
+	})
+
+	if total := alloc["video"] + alloc["audio"]; math.Abs(float64(total-900_000)) > 1 {
+		t.Errorf("sum of allocations = %v, want 900000", total)
+	}
+	if ratio := float64(alloc["video"]) / float64(alloc["audio"]); math.Abs(ratio-3) > 0.01 {
+		t.Errorf("video/audio allocation ratio = %v, want 3", ratio)
+	}
+}
+
+func TestStreamGroupClampsToMinAndRedistributes(t *testing.T) {
+	now := time.Now()
+	config := DefaultConfig()
+	config.RMIN = 1_000_000
+	config.RMAX = 1_000_000
+
+	g := NewStreamGroup(now, config)
+	g.AddStream("audio", 1, 100_000, 200_000) // needs at least 100kbps
+	g.AddStream("video", 99, 0, config.RMAX)
+
+	alloc := g.OnReceiveFeedbackReport(now, &FeedbackReport{
+		RecommendedRateAdaptionMode: RateAdaptionModeAcceleratedRampUp,
+		ReceivingRate:               config.RMAX,
+	})
+
+	if alloc["audio"] != 100_000 {
+		t.Errorf("audio allocation = %v, want clamped to its minRate 100000", alloc["audio"])
+	}
+	if want := BitsPerSecond(900_000); alloc["video"] != want {
+		t.Errorf("video allocation = %v, want remaining %v", alloc["video"], want)
+	}
+}
+
+func TestStreamGroupScalesDownWhenOversubscribed(t *testing.T) {
+	now := time.Now()
+	config := DefaultConfig()
+	config.RMIN = 100_000
+	config.RMAX = 100_000
+
+	g := NewStreamGroup(now, config)
+	g.AddStream("a", 1, 80_000, config.RMAX)
+	g.AddStream("b", 1, 80_000, config.RMAX)
+
+	alloc := g.OnReceiveFeedbackReport(now, &FeedbackReport{
+		RecommendedRateAdaptionMode: RateAdaptionModeAcceleratedRampUp,
+		ReceivingRate:               config.RMAX,
+	})
+
+	if total := alloc["a"] + alloc["b"]; math.Abs(float64(total-100_000)) > 1 {
+		t.Errorf("sum of allocations = %v, want 100000 (r_ref), not the sum of minRates (160000)", total)
+	}
+	if math.Abs(float64(alloc["a"]-alloc["b"])) > 1 {
+		t.Errorf("allocations = %v/%v, want equal shares of the budget since both minRates are equal", alloc["a"], alloc["b"])
+	}
+}
+
+func TestRecommendLayer(t *testing.T) {
+	layers := []BitsPerSecond{100_000, 300_000, 900_000}
+
+	cases := []struct {
+		rate BitsPerSecond
+		want int
+	}{
+		{rate: 50_000, want: 0},
+		{rate: 100_000, want: 0},
+		{rate: 350_000, want: 1},
+		{rate: 1_000_000, want: 2},
+	}
+
+	for _, c := range cases {
+		if got := RecommendLayer(c.rate, layers); got != c.want {
+			t.Errorf("RecommendLayer(%v) = %v, want %v", c.rate, got, c.want)
+		}
+	}
+}
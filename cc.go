@@ -0,0 +1,110 @@
+package nada
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/muxable/nada/cc"
+)
+
+// controller adapts a Sender to the generic cc.CongestionController
+// interface, so callers can A/B NADA against other controllers (e.g.
+// cc.GCC) registered in package cc.
+type controller struct {
+	lock   sync.Mutex
+	config Config
+	sender *Sender
+
+	// receiver derives ReceivingRate/AggregatedCongestionSignal from
+	// feedback.Arrivals, for callers (e.g. cc.Simulator) that only know
+	// individual packet arrivals rather than an already-aggregated
+	// NADA signal. Created lazily on first use.
+	receiver *Receiver
+}
+
+var _ cc.CongestionController = (*controller)(nil)
+
+// New returns a NADA implementation of cc.CongestionController.
+//
+// NADA needs no per-packet send bookkeeping: feedback carries either an
+// already-aggregated signal or Arrivals that include each packet's
+// SentTime. OnPacket is therefore a no-op for this controller: it exists
+// only to satisfy the shared interface for controllers (like cc.GCC) that
+// need per-packet bookkeeping.
+func New(now time.Time, config Config) cc.CongestionController {
+	return &controller{config: config, sender: NewSender(now, config)}
+}
+
+// Name implements cc.CongestionController.
+func (c *controller) Name() string { return "nada" }
+
+// OnPacket implements cc.CongestionController. See New's doc comment.
+func (c *controller) OnPacket(seq uint16, sentTime time.Time, size float64) {}
+
+// OnFeedback implements cc.CongestionController, translating the generic
+// feedback payload into a FeedbackReport. When feedback carries Arrivals
+// (e.g. from cc.Simulator, which only knows per-packet arrival times),
+// those are replayed through a Receiver to derive ReceivingRate and
+// AggregatedCongestionSignal exactly as a real NADA receiver would;
+// otherwise feedback.ReceivingRate and AggregatedCongestionSignal are used
+// directly, and an AggregatedCongestionSignal of zero or less is treated as the
+// accelerated ramp-up signal, mirroring Receiver's own "no loss, no
+// queuing delay" condition.
+func (c *controller) OnFeedback(feedback cc.Feedback) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var report *FeedbackReport
+	if len(feedback.Arrivals) > 0 {
+		report = c.reportFromArrivals(feedback)
+	} else {
+		mode := RateAdaptionModeGradualUpdate
+		if feedback.AggregatedCongestionSignal <= 0 {
+			mode = RateAdaptionModeAcceleratedRampUp
+		}
+		report = &FeedbackReport{
+			RecommendedRateAdaptionMode: mode,
+			AggregatedCongestionSignal:  feedback.AggregatedCongestionSignal,
+			ReceivingRate:               BitsPerSecond(feedback.ReceivingRate),
+		}
+	}
+
+	c.sender.OnReceiveFeedbackReport(feedback.Time, report)
+}
+
+// reportFromArrivals replays feedback.Arrivals, in arrival order, through
+// c.receiver and returns the resulting FeedbackReport.
+func (c *controller) reportFromArrivals(feedback cc.Feedback) *FeedbackReport {
+	if c.receiver == nil {
+		c.receiver = NewReceiver(feedback.Time, c.config)
+	}
+
+	arrivals := make([]cc.PacketArrival, len(feedback.Arrivals))
+	copy(arrivals, feedback.Arrivals)
+	sort.Slice(arrivals, func(i, j int) bool { return arrivals[i].ArrivalTime.Before(arrivals[j].ArrivalTime) })
+
+	for _, a := range arrivals {
+		if !a.Received {
+			continue
+		}
+		ecn := ECNNotECT
+		if a.ECNCE {
+			ecn = ECNCE
+		}
+		_ = c.receiver.OnReceiveMediaPacket(a.ArrivalTime, a.SentTime, a.Seq, ecn, Bits(a.Size))
+	}
+
+	return c.receiver.BuildFeedbackReport()
+}
+
+// TargetRate implements cc.CongestionController.
+func (c *controller) TargetRate() float64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return float64(c.sender.TargetRate())
+}
+
+func init() {
+	cc.Register("nada", func() cc.CongestionController { return New(time.Now(), DefaultConfig()) })
+}
@@ -0,0 +1,51 @@
+// Package cc defines a congestion controller abstraction shared by every
+// rate control algorithm in this repository (nada.New, NewGCCController),
+// so callers can swap one out for another behind a single API, and so
+// Simulator can replay the same trace through each for comparison.
+package cc
+
+import "time"
+
+// PacketArrival describes one packet covered by an incoming feedback
+// message, independent of the wire format that carried it.
+type PacketArrival struct {
+	Seq         uint16
+	SentTime    time.Time
+	ArrivalTime time.Time // zero if the packet was not received
+	Size        float64   // bits
+	Received    bool
+	ECNCE       bool
+}
+
+// Feedback is the generic feedback payload a CongestionController
+// consumes. Controllers that reason about individual packets (e.g. a
+// delay-gradient-based controller) use Arrivals; controllers that reason
+// about an already-aggregated signal (e.g. NADA) use ReceivingRate and
+// AggregatedCongestionSignal instead. A controller is free to ignore
+// whichever half of the payload it doesn't need.
+type Feedback struct {
+	Time     time.Time
+	Arrivals []PacketArrival
+
+	ReceivingRate              float64 // bits per second
+	AggregatedCongestionSignal time.Duration
+}
+
+// CongestionController is the common interface implemented by every rate
+// controller in this repository.
+type CongestionController interface {
+	// Name identifies the controller, e.g. for Recorder output.
+	Name() string
+	// OnPacket records that a packet was sent, for later correlation with
+	// feedback describing its arrival.
+	OnPacket(seq uint16, sentTime time.Time, size float64)
+	// OnFeedback processes an incoming feedback message and updates the
+	// controller's rate estimate.
+	OnFeedback(feedback Feedback)
+	// TargetRate returns the controller's current rate estimate, in bits
+	// per second.
+	TargetRate() float64
+}
+
+// Factory constructs a new, independent CongestionController instance.
+type Factory func() CongestionController
@@ -0,0 +1,227 @@
+package cc
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// GCC implements the delay-based Kalman-filter trend line estimator plus
+// loss-based AIMD controller described in draft-ietf-rmcat-gcc, the same
+// algorithm GStreamer's rtpgccbwe implements. This is a condensed,
+// single-flow port intended for A/B comparison against nada.New's
+// controller in Simulator, not a certified implementation of the draft.
+type GCC struct {
+	lock sync.Mutex
+
+	// sent tracks packets awaiting feedback, for computing inter-departure
+	// and inter-arrival deltas.
+	sent map[uint16]sentPacket
+
+	haveGroup     bool
+	lastSentTime  time.Time
+	lastArrival   time.Time
+	lastGroupSize float64
+
+	// Trend-line Kalman filter over the inter-group delay variation d(i),
+	// following the draft's arrival-time model.
+	slope    float64 // m_hat, the estimated delay trend
+	varV     float64 // estimated measurement noise variance
+	varP     float64 // estimated process (slope) variance
+	overuse  float64 // signed trend compared against gammaThr for over-use detection
+	gammaThr float64 // gamma_1, the adaptive overuse threshold
+
+	lastRateUpdate time.Time
+	rate           float64 // A_hat, the delay-based estimate
+	state          gccState
+
+	lossTarget float64 // loss-based estimate
+}
+
+type sentPacket struct {
+	sentTime time.Time
+	size     float64
+}
+
+type gccState int
+
+const (
+	gccStateHold gccState = iota
+	gccStateIncrease
+	gccStateDecrease
+)
+
+// NewGCCController creates a GCC controller with a conservative starting
+// rate, matching the recommended RMIN-equivalent starting point used
+// elsewhere in this repository.
+func NewGCCController(startRate float64) *GCC {
+	return &GCC{
+		sent:       map[uint16]sentPacket{},
+		varP:       10,
+		varV:       10, // initial measurement noise estimate, ms^2
+		gammaThr:   12.5,
+		rate:       startRate,
+		lossTarget: startRate,
+		state:      gccStateIncrease,
+	}
+}
+
+// Name implements CongestionController.
+func (g *GCC) Name() string { return "gcc" }
+
+// OnPacket implements CongestionController.
+func (g *GCC) OnPacket(seq uint16, sentTime time.Time, size float64) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.sent[seq] = sentPacket{sentTime: sentTime, size: size}
+}
+
+// OnFeedback implements CongestionController. It derives a one-way delay
+// gradient and a loss ratio from feedback.Arrivals and updates the
+// combined rate estimate.
+func (g *GCC) OnFeedback(feedback Feedback) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if len(feedback.Arrivals) == 0 {
+		return
+	}
+
+	var received, lost int
+	var groupSentEnd, groupArrivalEnd time.Time
+	var groupSize float64
+
+	for _, a := range feedback.Arrivals {
+		delete(g.sent, a.Seq)
+
+		if !a.Received {
+			lost++
+			continue
+		}
+		received++
+
+		groupSize += a.Size
+		if a.SentTime.After(groupSentEnd) {
+			groupSentEnd = a.SentTime
+		}
+		if a.ArrivalTime.After(groupArrivalEnd) {
+			groupArrivalEnd = a.ArrivalTime
+		}
+	}
+
+	if received+lost > 0 {
+		lossRatio := float64(lost) / float64(received+lost)
+		g.updateLossBased(lossRatio)
+	}
+
+	if received > 0 {
+		g.updateDelayBased(groupSentEnd, groupArrivalEnd, groupSize, feedback.Time)
+	}
+}
+
+// updateDelayBased runs one step of the trend-line filter and the
+// increase/hold/decrease state machine described in draft-ietf-rmcat-gcc
+// Section 5.
+func (g *GCC) updateDelayBased(sentEnd, arrivalEnd time.Time, size float64, now time.Time) {
+	if !g.haveGroup {
+		g.haveGroup = true
+		g.lastSentTime = sentEnd
+		g.lastArrival = arrivalEnd
+		g.lastGroupSize = size
+		g.lastRateUpdate = now
+		return
+	}
+
+	sendDelta := sentEnd.Sub(g.lastSentTime).Seconds() * 1000
+	arrivalDelta := arrivalEnd.Sub(g.lastArrival).Seconds() * 1000
+	d := arrivalDelta - sendDelta // ms
+
+	g.lastSentTime = sentEnd
+	g.lastArrival = arrivalEnd
+	g.lastGroupSize = size
+
+	// Kalman filter update of the trend slope, as in the draft's Appendix.
+	const processNoise = 1e-3
+	const chi = 0.1
+
+	g.varP += processNoise
+	k := g.varP / (g.varV + g.varP)
+	residual := d - g.slope
+	g.slope += k * residual
+	g.varP = (1 - k) * g.varP
+	g.varV = chi*g.varV + (1-chi)*residual*residual
+	if g.varV < 1 {
+		g.varV = 1
+	}
+
+	g.overuse = g.slope
+
+	switch {
+	case g.overuse > g.gammaThr:
+		g.state = gccStateDecrease
+	case g.overuse < -g.gammaThr:
+		g.state = gccStateHold
+	case g.state == gccStateDecrease:
+		g.state = gccStateHold
+	default:
+		g.state = gccStateIncrease
+	}
+
+	// Adapt the threshold toward the observed trend magnitude, as the
+	// draft recommends, bounded to a sane range.
+	k_g := 0.01
+	g.gammaThr += k_g * (math.Abs(g.overuse) - g.gammaThr)
+	if g.gammaThr < 6 {
+		g.gammaThr = 6
+	}
+	if g.gammaThr > 600 {
+		g.gammaThr = 600
+	}
+
+	delta := now.Sub(g.lastRateUpdate)
+	g.lastRateUpdate = now
+	if delta <= 0 {
+		return
+	}
+
+	switch g.state {
+	case gccStateIncrease:
+		// Additive increase, approximated as a small fraction of the
+		// group's own bitrate observed over this interval. size is
+		// already in bits (see PacketArrival.Size).
+		g.rate += (size / delta.Seconds()) * 0.05
+	case gccStateDecrease:
+		g.rate *= 0.85
+	}
+	if g.rate < 0 {
+		g.rate = 0
+	}
+}
+
+// updateLossBased applies the AIMD loss controller from the draft's
+// Section 6.2: back off multiplicatively above a high-loss threshold, hold
+// between the thresholds, and increase multiplicatively by 5% below the
+// low-loss threshold.
+func (g *GCC) updateLossBased(lossRatio float64) {
+	switch {
+	case lossRatio > 0.1:
+		g.lossTarget *= 1 - 0.5*lossRatio
+	case lossRatio < 0.02:
+		g.lossTarget *= 1.05
+	}
+}
+
+// TargetRate implements CongestionController, returning the lesser of the
+// delay-based and loss-based estimates, as the draft combines them.
+func (g *GCC) TargetRate() float64 {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.lossTarget < g.rate {
+		return g.lossTarget
+	}
+	return g.rate
+}
+
+func init() {
+	Register("gcc", func() CongestionController { return NewGCCController(150_000) })
+}
@@ -0,0 +1,69 @@
+package cc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGCCIncreasesOnCleanPath(t *testing.T) {
+	g := NewGCCController(300_000)
+
+	now := time.Now()
+	before := g.TargetRate()
+
+	// The delay-based estimate needs two packet groups to form a delay
+	// gradient, so send two one-second groups, each covered by its own
+	// feedback message.
+	for group := 0; group < 2; group++ {
+		var arrivals []PacketArrival
+		for i := group * 50; i < (group+1)*50; i++ {
+			sent := now.Add(time.Duration(i) * 20 * time.Millisecond)
+			g.OnPacket(uint16(i), sent, 2000)
+			arrivals = append(arrivals, PacketArrival{
+				Seq: uint16(i), SentTime: sent, ArrivalTime: sent.Add(10 * time.Millisecond),
+				Size: 2000, Received: true,
+			})
+		}
+		g.OnFeedback(Feedback{Time: now.Add(time.Duration(group+1) * time.Second), Arrivals: arrivals})
+	}
+	after := g.TargetRate()
+
+	if after <= before {
+		t.Errorf("TargetRate() = %v, want > starting rate %v on a clean path", after, before)
+	}
+}
+
+func TestGCCBacksOffOnHeavyLoss(t *testing.T) {
+	g := NewGCCController(1_000_000)
+
+	now := time.Now()
+	var arrivals []PacketArrival
+	for i := 0; i < 50; i++ {
+		sent := now.Add(time.Duration(i) * 20 * time.Millisecond)
+		g.OnPacket(uint16(i), sent, 2000)
+		received := i%2 == 0
+		a := PacketArrival{Seq: uint16(i), SentTime: sent, Size: 2000, Received: received}
+		if received {
+			a.ArrivalTime = sent.Add(10 * time.Millisecond)
+		}
+		arrivals = append(arrivals, a)
+	}
+
+	before := g.TargetRate()
+	g.OnFeedback(Feedback{Time: now.Add(time.Second), Arrivals: arrivals})
+	after := g.TargetRate()
+
+	if after >= before {
+		t.Errorf("TargetRate() = %v, want < starting rate %v after 50%% loss", after, before)
+	}
+}
+
+func TestGCCRegisteredByDefault(t *testing.T) {
+	factory, ok := Get("gcc")
+	if !ok {
+		t.Fatal(`Get("gcc") not found, want GCC to self-register via init`)
+	}
+	if name := factory().Name(); name != "gcc" {
+		t.Errorf("Name() = %q, want %q", name, "gcc")
+	}
+}
@@ -0,0 +1,42 @@
+package cc
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Recorder dumps per-feedback-tick controller decisions to CSV, for
+// offline analysis of one or more runs (e.g. comparing nada.New against
+// NewGCCController over the same Simulator trace).
+type Recorder struct {
+	w       *csv.Writer
+	started bool
+}
+
+// NewRecorder creates a Recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: csv.NewWriter(w)}
+}
+
+// Record writes one row: the controller name, the wall-clock time of the
+// decision, and its resulting target rate in bits per second.
+func (r *Recorder) Record(controller string, now time.Time, targetRate float64) error {
+	if !r.started {
+		if err := r.w.Write([]string{"controller", "time_unix_nano", "target_rate_bps"}); err != nil {
+			return err
+		}
+		r.started = true
+	}
+	err := r.w.Write([]string{
+		controller,
+		strconv.FormatInt(now.UnixNano(), 10),
+		strconv.FormatFloat(targetRate, 'f', -1, 64),
+	})
+	if err != nil {
+		return err
+	}
+	r.w.Flush()
+	return r.w.Error()
+}
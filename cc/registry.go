@@ -0,0 +1,29 @@
+package cc
+
+import "sort"
+
+var registry = map[string]Factory{}
+
+// Register makes a CongestionController Factory available under name, for
+// lookup by Simulator or other callers that want to run the same trace
+// through every registered controller.
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// Get looks up a previously Registered Factory by name.
+func Get(name string) (Factory, bool) {
+	f, ok := registry[name]
+	return f, ok
+}
+
+// Names returns every registered controller name, sorted for stable
+// iteration order.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
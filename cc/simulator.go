@@ -0,0 +1,81 @@
+package cc
+
+import (
+	"sort"
+	"time"
+)
+
+// TraceEvent describes one packet's journey across a simulated network
+// path, for replay through a CongestionController by Simulator.
+type TraceEvent struct {
+	Seq         uint16
+	SentTime    time.Time
+	ArrivalTime time.Time // zero if the packet was lost
+	Size        float64   // bits
+	ECNCE       bool
+}
+
+// Simulator replays a fixed trace through any CongestionController,
+// batching feedback every FeedbackInterval, so that NADA-vs-GCC (or any
+// other pair of registered controllers) comparisons are reproducible in
+// CI: the same trace drives every controller identically.
+type Simulator struct {
+	Trace            []TraceEvent
+	FeedbackInterval time.Duration
+	Recorder         *Recorder
+}
+
+// Run replays s.Trace through controller and returns the sequence of
+// target rates observed at each feedback tick.
+func (s *Simulator) Run(controller CongestionController) []float64 {
+	trace := make([]TraceEvent, len(s.Trace))
+	copy(trace, s.Trace)
+	sort.Slice(trace, func(i, j int) bool { return trace[i].SentTime.Before(trace[j].SentTime) })
+
+	interval := s.FeedbackInterval
+	if interval <= 0 {
+		interval = 100 * time.Millisecond
+	}
+
+	var rates []float64
+	var pending []PacketArrival
+	var nextFeedback time.Time
+
+	flush := func(now time.Time) {
+		if len(pending) == 0 {
+			return
+		}
+		controller.OnFeedback(Feedback{Time: now, Arrivals: pending})
+		pending = nil
+
+		rate := controller.TargetRate()
+		rates = append(rates, rate)
+		if s.Recorder != nil {
+			_ = s.Recorder.Record(controller.Name(), now, rate)
+		}
+	}
+
+	for _, e := range trace {
+		controller.OnPacket(e.Seq, e.SentTime, e.Size)
+
+		if nextFeedback.IsZero() {
+			nextFeedback = e.SentTime.Add(interval)
+		}
+		for e.SentTime.After(nextFeedback) {
+			flush(nextFeedback)
+			nextFeedback = nextFeedback.Add(interval)
+		}
+
+		pending = append(pending, PacketArrival{
+			Seq:         e.Seq,
+			SentTime:    e.SentTime,
+			ArrivalTime: e.ArrivalTime,
+			Size:        e.Size,
+			Received:    !e.ArrivalTime.IsZero(),
+			ECNCE:       e.ECNCE,
+		})
+	}
+	flush(nextFeedback)
+
+	return rates
+}
@@ -0,0 +1,53 @@
+package cc
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func buildTrace(n int, dropEvery int) []TraceEvent {
+	now := time.Now()
+	trace := make([]TraceEvent, 0, n)
+	for i := 0; i < n; i++ {
+		sent := now.Add(time.Duration(i) * 20 * time.Millisecond)
+		e := TraceEvent{Seq: uint16(i), SentTime: sent, Size: 1200}
+		if dropEvery == 0 || i%dropEvery != 0 {
+			e.ArrivalTime = sent.Add(15 * time.Millisecond)
+		}
+		trace = append(trace, e)
+	}
+	return trace
+}
+
+func TestSimulatorRun(t *testing.T) {
+	sim := &Simulator{
+		Trace:            buildTrace(200, 10),
+		FeedbackInterval: 100 * time.Millisecond,
+	}
+
+	rates := sim.Run(NewGCCController(300_000))
+	if len(rates) == 0 {
+		t.Fatal("Run() returned no rate samples")
+	}
+	for _, r := range rates {
+		if r < 0 {
+			t.Errorf("target rate = %v, want >= 0", r)
+		}
+	}
+}
+
+func TestSimulatorWithRecorder(t *testing.T) {
+	var buf bytes.Buffer
+	sim := &Simulator{
+		Trace:            buildTrace(100, 0),
+		FeedbackInterval: 50 * time.Millisecond,
+		Recorder:         NewRecorder(&buf),
+	}
+
+	sim.Run(NewGCCController(300_000))
+
+	if buf.Len() == 0 {
+		t.Error("Recorder wrote no output")
+	}
+}
@@ -0,0 +1,66 @@
+package nada
+
+import (
+	"testing"
+	"time"
+
+	"github.com/muxable/nada/cc"
+)
+
+func TestNewImplementsCongestionController(t *testing.T) {
+	now := time.Now()
+	controller := New(now, DefaultConfig())
+
+	if controller.Name() != "nada" {
+		t.Errorf("Name() = %q, want %q", controller.Name(), "nada")
+	}
+
+	controller.OnPacket(0, now, 1000)
+	controller.OnFeedback(cc.Feedback{
+		Time:          now.Add(100 * time.Millisecond),
+		ReceivingRate: 500_000,
+	})
+
+	if controller.TargetRate() <= 0 {
+		t.Errorf("TargetRate() = %v, want > 0", controller.TargetRate())
+	}
+}
+
+func TestSimulatorMovesNADARate(t *testing.T) {
+	now := time.Now()
+	trace := make([]cc.TraceEvent, 0, 500)
+	for i := 0; i < 500; i++ {
+		sent := now.Add(time.Duration(i) * 20 * time.Millisecond)
+		trace = append(trace, cc.TraceEvent{
+			Seq:         uint16(i),
+			SentTime:    sent,
+			ArrivalTime: sent.Add(15 * time.Millisecond),
+			Size:        1200 * 8, // a 1200-byte packet, in bits
+		})
+	}
+
+	sim := &cc.Simulator{
+		Trace:            trace,
+		FeedbackInterval: 100 * time.Millisecond,
+	}
+
+	config := DefaultConfig()
+	rates := sim.Run(New(now, config))
+
+	if len(rates) == 0 {
+		t.Fatal("Run() returned no rate samples")
+	}
+	if last := rates[len(rates)-1]; last <= float64(config.RMIN) {
+		t.Errorf("target rate after a clean 500-packet trace = %v, want > RMIN (%v)", last, config.RMIN)
+	}
+}
+
+func TestNewRegisteredInCCRegistry(t *testing.T) {
+	factory, ok := cc.Get("nada")
+	if !ok {
+		t.Fatal(`cc.Get("nada") not found, want nada to self-register via init`)
+	}
+	if name := factory().Name(); name != "nada" {
+		t.Errorf("Name() = %q, want %q", name, "nada")
+	}
+}
@@ -0,0 +1,101 @@
+package nada
+
+import "time"
+
+// Config holds the tunable constants of the NADA algorithm, as described in
+// RFC 8698 Section 4.
+type Config struct {
+	// α is ALPHA, the EWMA smoothing factor used to update the packet loss
+	// and ECN marking ratio estimates.
+	α float64
+	// λ is LAMBDA, the exponential warping factor applied to queuing delay
+	// once it exceeds DelayThreshold.
+	λ float64
+
+	// LogWindow is the duration of the sliding window used to compute
+	// instantaneous loss, marking, and receiving rate estimates.
+	LogWindow time.Duration
+	// QueueingDelayThreshold is the queuing delay above which a packet is
+	// considered to be experiencing congestion, for the purposes of
+	// recommending a rate adaption mode.
+	QueueingDelayThreshold time.Duration
+	// DelayThreshold is QTH, the queuing delay above which the non-linear
+	// delay warping in equivalentDelay kicks in.
+	DelayThreshold time.Duration
+
+	// ReferenceDelayLoss is DLOSS, the reference delay penalty applied for
+	// packet loss.
+	ReferenceDelayLoss time.Duration
+	// ReferencePacketLossRatio is PLR_REF, the reference packet loss ratio.
+	ReferencePacketLossRatio float64
+	// ReferenceDelayMarking is DMARK, the reference delay penalty applied
+	// for classic ECN-CE marking.
+	ReferenceDelayMarking time.Duration
+	// ReferencePacketMarkingRatio is PMRK_REF, the reference classic
+	// ECN-CE marking ratio.
+	ReferencePacketMarkingRatio float64
+	// ReferenceDelayL4SMarking is the reference delay penalty applied for
+	// L4S-style ECT(1) marking. Per the L4S design (RFC 9331), each mark
+	// is a fine-grained congestion signal rather than a sign of a fully
+	// congested queue, so this should be configured much smaller than
+	// ReferenceDelayMarking.
+	ReferenceDelayL4SMarking time.Duration
+	// ReferenceL4SMarkingRatio is the reference L4S ECT(1) marking ratio.
+	ReferenceL4SMarkingRatio float64
+
+	// PRIO is the priority weight assigned to this flow.
+	PRIO float64
+	// XREF is the reference congestion signal level.
+	XREF time.Duration
+	// KAPPA is the gain factor applied during Gradual Update.
+	KAPPA float64
+	// ETA is the gain factor applied to the rate of change of the
+	// congestion signal during Gradual Update.
+	ETA float64
+	// TAU_O is the observed feedback interval used to normalize the
+	// Gradual Update step.
+	TAU_O time.Duration
+	// RMIN is the minimum rate r_ref may take.
+	RMIN BitsPerSecond
+	// RMAX is the maximum rate r_ref may take.
+	RMAX BitsPerSecond
+	// GAMMA_MAX is the maximum rate increase ratio allowed in a single
+	// Accelerated Ramp-Up step.
+	GAMMA_MAX float64
+	// MinPacketRateForRampUp is the minimum observed receiving packet
+	// rate required before Accelerated Ramp-Up is recommended. This
+	// avoids spurious ramp-ups on low-rate streams such as silent,
+	// DTX-heavy audio, where the absence of loss and queuing delay is not
+	// a meaningful signal of spare capacity.
+	MinPacketRateForRampUp PacketsPerSecond
+}
+
+// DefaultConfig returns the constants recommended by RFC 8698 Section 4.
+func DefaultConfig() Config {
+	return Config{
+		α: 0.1,
+		λ: 0.5,
+
+		LogWindow:              500 * time.Millisecond,
+		QueueingDelayThreshold: 10 * time.Millisecond,
+		DelayThreshold:         50 * time.Millisecond,
+
+		ReferenceDelayLoss:          500 * time.Millisecond,
+		ReferencePacketLossRatio:    0.01,
+		ReferenceDelayMarking:       50 * time.Millisecond,
+		ReferencePacketMarkingRatio: 0.01,
+		ReferenceDelayL4SMarking:    5 * time.Millisecond,
+		ReferenceL4SMarkingRatio:    0.1,
+
+		PRIO:      1.0,
+		XREF:      10 * time.Millisecond,
+		KAPPA:     0.5,
+		ETA:       2.0,
+		TAU_O:     500 * time.Millisecond,
+		RMIN:      150_000,
+		RMAX:      1_500_000,
+		GAMMA_MAX: 0.5,
+
+		MinPacketRateForRampUp: 5,
+	}
+}
@@ -0,0 +1,26 @@
+package nada
+
+import "testing"
+
+// TestECNMatchesRFC3168Codepoints verifies ECN's values match the two-bit
+// ECN field layout in the IP header's TOS/Traffic Class byte (RFC 3168
+// Section 5): Not-ECT=00, ECT(1)=01, ECT(0)=10, CE=11. Getting this wrong
+// silently swaps L4S (ECT(1)) and classic ECT(0) classification for any
+// caller that maps the raw two-bit codepoint to ECN by numeric value.
+func TestECNMatchesRFC3168Codepoints(t *testing.T) {
+	cases := []struct {
+		tos  byte // the two ECN bits, as they appear in the low bits of the TOS byte
+		want ECN
+	}{
+		{tos: 0b00, want: ECNNotECT},
+		{tos: 0b01, want: ECNECT1},
+		{tos: 0b10, want: ECNECT0},
+		{tos: 0b11, want: ECNCE},
+	}
+
+	for _, c := range cases {
+		if got := ECN(c.tos); got != c.want {
+			t.Errorf("ECN(%02b) = %v, want %v", c.tos, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package nada
+
+import "time"
+
+// FeedbackReport is the set of values a Receiver periodically sends back to
+// a Sender, as described in RFC 8698 Section 4.2.
+type FeedbackReport struct {
+	// RecommendedRateAdaptionMode is rmode, the rate adaption mode the
+	// receiver recommends the sender use for this tick.
+	RecommendedRateAdaptionMode RateAdaptionMode
+	// AggregatedCongestionSignal is x_curr, the current aggregated
+	// congestion signal, expressed as an equivalent delay.
+	AggregatedCongestionSignal time.Duration
+	// ReceivingRate is r_recv, the measured receiving rate.
+	ReceivingRate BitsPerSecond
+	// ReceivingPacketRate is the measured receiving packet rate,
+	// independent of packet size. Small-packet flows (e.g. audio-only or
+	// DTX-heavy streams) can have a low ReceivingRate despite a healthy
+	// ReceivingPacketRate; Sender uses this to avoid overreacting to their
+	// naturally noisier per-packet timing.
+	ReceivingPacketRate PacketsPerSecond
+}
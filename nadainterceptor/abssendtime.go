@@ -0,0 +1,80 @@
+package nadainterceptor
+
+import (
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtp"
+)
+
+// absSendTimeURI is the URI of the abs-send-time RTP header extension
+// (http://www.webrtc.org/experiments/rtp-hdrext/abs-send-time), used here
+// as the one-way delay source required by nada.Receiver.
+const absSendTimeURI = "http://www.webrtc.org/experiments/rtp-hdrext/abs-send-time"
+
+// ecnAttributesKey is the interceptor.Attributes key a lower-level
+// interceptor (e.g. one reading the IP TOS byte) sets to report the
+// packet's two-bit ECN codepoint, as a nada.ECN value.
+const ecnAttributesKey = "nada.ecn"
+
+// timeNow exists so tests can observe a fixed clock; production code always
+// uses time.Now.
+var timeNow = time.Now
+
+// rtpExtensionID looks up the numeric header extension ID registered for
+// uri in streamInfo, returning 0 if it is not present.
+func rtpExtensionID(streamInfo *interceptor.StreamInfo, uri string) uint8 {
+	for _, e := range streamInfo.RTPHeaderExtensions {
+		if e.URI == uri {
+			return uint8(e.ID)
+		}
+	}
+	return 0
+}
+
+// absSendTimeEpoch is the number of abs-send-time units per second: the
+// value is 6.18 fixed-point seconds, so its 24 bits wrap every 64 seconds,
+// and absolute timestamps are reconstructed relative to time.Now() at the
+// call site rather than decoded as wall-clock time directly.
+const absSendTimeEpoch = 1 << 18 // fractional seconds resolution (2^18 per second)
+
+// absSendTime extracts the abs-send-time extension from pkt, if present,
+// and converts it to an absolute time.Time by anchoring the 24-bit wrapped
+// value to the current wall clock.
+func absSendTime(pkt *rtp.Packet, extensionID uint8) (time.Time, bool) {
+	if extensionID == 0 {
+		return time.Time{}, false
+	}
+
+	ext := pkt.GetExtension(extensionID)
+	if len(ext) != 3 {
+		return time.Time{}, false
+	}
+
+	raw := uint32(ext[0])<<16 | uint32(ext[1])<<8 | uint32(ext[2])
+	seconds := float64(raw) / float64(absSendTimeEpoch)
+
+	now := timeNow()
+	whole, frac := splitSeconds(now)
+	nowWrapped := float64(whole%64) + frac
+
+	// Align the wrapped abs-send-time value to the current wall clock's
+	// position in its 64-second cycle, then correct for wraparound relative
+	// to now.
+	sent := now.Add(time.Duration((seconds - nowWrapped) * float64(time.Second)))
+	for sent.Sub(now) > 32*time.Second {
+		sent = sent.Add(-64 * time.Second)
+	}
+	for now.Sub(sent) > 32*time.Second {
+		sent = sent.Add(64 * time.Second)
+	}
+
+	return sent, true
+}
+
+func splitSeconds(t time.Time) (int64, float64) {
+	unix := t.UnixNano()
+	whole := unix / int64(time.Second)
+	frac := float64(unix%int64(time.Second)) / float64(time.Second)
+	return whole, frac
+}
@@ -0,0 +1,152 @@
+package nadainterceptor
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/muxable/nada"
+	"github.com/pion/rtcp"
+)
+
+// feedbackFMT is the payload-specific feedback (PSFB, RTCP packet type 206)
+// FMT value used to carry a nada.FeedbackReport. It is drawn from the
+// unassigned range of RFC 4585 Section 6.3's FMT registry; it is not
+// registered with IANA and is only meaningful between two NADA peers.
+const feedbackFMT = 30
+
+// feedbackLength is the length, in bytes, of the FCI payload carried by a
+// FeedbackPacket: 1 byte mode + 4 bytes congestion signal (microseconds) +
+// 4 bytes receiving rate (bits per second) + 4 bytes receiving packet rate
+// (packets per second), all rounded up to a 32-bit boundary per RFC 3550
+// Section 6.1.
+const feedbackLength = 16
+
+// feedbackPacketLength is the full on-wire packet size, in bytes: 4 bytes
+// of RTCP header, 8 bytes of sender/media SSRCs, and feedbackLength bytes
+// of FCI.
+const feedbackPacketLength = 4 + 8 + feedbackLength
+
+// errWrongType is returned by Unmarshal when rawPacket is not a
+// FeedbackPacket.
+var errWrongType = errors.New("nadainterceptor: wrong packet type")
+
+// errPacketTooShort is returned by Unmarshal when rawPacket is too short
+// to contain a FeedbackPacket.
+var errPacketTooShort = errors.New("nadainterceptor: packet too short")
+
+// FeedbackPacket is an RTCP payload-specific feedback packet carrying a
+// nada.FeedbackReport from a NADA receiver to its sender.
+type FeedbackPacket struct {
+	SenderSSRC uint32
+	MediaSSRC  uint32
+	Report     nada.FeedbackReport
+}
+
+var _ rtcp.Packet = (*FeedbackPacket)(nil)
+
+// Marshal encodes the packet according to the RTCP header defined in
+// RFC 4585 Section 6.1, using feedbackFMT to identify the FCI contents.
+func (p *FeedbackPacket) Marshal() ([]byte, error) {
+	fci := make([]byte, feedbackLength)
+	fci[0] = byte(p.Report.RecommendedRateAdaptionMode)
+	binary.BigEndian.PutUint32(fci[1:5], uint32(p.Report.AggregatedCongestionSignal/time.Microsecond))
+	binary.BigEndian.PutUint32(fci[5:9], uint32(p.Report.ReceivingRate))
+	binary.BigEndian.PutUint32(fci[9:13], uint32(p.Report.ReceivingPacketRate))
+
+	header := rtcp.Header{
+		Count:   feedbackFMT,
+		Type:    rtcp.TypePayloadSpecificFeedback,
+		Padding: false,
+		Length:  uint16(feedbackPacketLength/4 - 1),
+	}
+
+	headerBytes, err := header.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := make([]byte, 8+len(fci))
+	binary.BigEndian.PutUint32(payload[0:4], p.SenderSSRC)
+	binary.BigEndian.PutUint32(payload[4:8], p.MediaSSRC)
+	copy(payload[8:], fci)
+
+	return append(headerBytes, payload...), nil
+}
+
+// MarshalSize returns the size, in bytes, of the packet Marshal produces.
+func (p *FeedbackPacket) MarshalSize() int {
+	return feedbackPacketLength
+}
+
+// Unmarshal decodes a FeedbackPacket previously produced by Marshal.
+func (p *FeedbackPacket) Unmarshal(rawPacket []byte) error {
+	var header rtcp.Header
+	if err := header.Unmarshal(rawPacket); err != nil {
+		return err
+	}
+	if header.Type != rtcp.TypePayloadSpecificFeedback || header.Count != feedbackFMT {
+		return errWrongType
+	}
+
+	payload := rawPacket[4:]
+	if len(payload) < 8+feedbackLength {
+		return errPacketTooShort
+	}
+
+	p.SenderSSRC = binary.BigEndian.Uint32(payload[0:4])
+	p.MediaSSRC = binary.BigEndian.Uint32(payload[4:8])
+
+	fci := payload[8:]
+	p.Report.RecommendedRateAdaptionMode = nada.RateAdaptionMode(fci[0])
+	p.Report.AggregatedCongestionSignal = time.Duration(binary.BigEndian.Uint32(fci[1:5])) * time.Microsecond
+	p.Report.ReceivingRate = nada.BitsPerSecond(binary.BigEndian.Uint32(fci[5:9]))
+	p.Report.ReceivingPacketRate = nada.PacketsPerSecond(binary.BigEndian.Uint32(fci[9:13]))
+
+	return nil
+}
+
+// Header returns the RTCP header that Marshal would produce for this packet.
+func (p *FeedbackPacket) Header() rtcp.Header {
+	return rtcp.Header{
+		Count:  feedbackFMT,
+		Type:   rtcp.TypePayloadSpecificFeedback,
+		Length: uint16(feedbackPacketLength/4 - 1),
+	}
+}
+
+// DestinationSSRC implements rtcp.Packet.
+func (p *FeedbackPacket) DestinationSSRC() []uint32 {
+	return []uint32{p.MediaSSRC}
+}
+
+// extractFeedbackPackets walks a raw (possibly compound) RTCP buffer and
+// returns the FeedbackPackets it contains. rtcp.Unmarshal can't be used for
+// this: it dispatches each sub-packet to a fixed set of built-in types by
+// RTCP type/FMT and has no way to register feedbackFMT against
+// FeedbackPacket, so it would hand back an *rtcp.RawPacket instead. Other
+// sub-packets in the compound packet are skipped using the RTCP header's
+// length field, per RFC 3550 Section 6.1.
+func extractFeedbackPackets(b []byte) []*FeedbackPacket {
+	var out []*FeedbackPacket
+	for len(b) >= 4 {
+		var header rtcp.Header
+		if err := header.Unmarshal(b); err != nil {
+			return out
+		}
+		packetLength := (int(header.Length) + 1) * 4
+		if packetLength > len(b) {
+			return out
+		}
+
+		if header.Type == rtcp.TypePayloadSpecificFeedback && header.Count == feedbackFMT {
+			fb := &FeedbackPacket{}
+			if err := fb.Unmarshal(b[:packetLength]); err == nil {
+				out = append(out, fb)
+			}
+		}
+
+		b = b[packetLength:]
+	}
+	return out
+}
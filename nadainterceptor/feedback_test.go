@@ -0,0 +1,72 @@
+package nadainterceptor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/muxable/nada"
+)
+
+func TestFeedbackPacketRoundTrip(t *testing.T) {
+	cases := []struct {
+		name string
+		pkt  FeedbackPacket
+	}{
+		{
+			name: "ramp up",
+			pkt: FeedbackPacket{
+				SenderSSRC: 1,
+				MediaSSRC:  2,
+				Report: nada.FeedbackReport{
+					RecommendedRateAdaptionMode: nada.RateAdaptionModeAcceleratedRampUp,
+					AggregatedCongestionSignal:  15 * time.Millisecond,
+					ReceivingRate:               300_000,
+					ReceivingPacketRate:         250,
+				},
+			},
+		},
+		{
+			name: "gradual update",
+			pkt: FeedbackPacket{
+				SenderSSRC: 0xdeadbeef,
+				MediaSSRC:  0xfeedface,
+				Report: nada.FeedbackReport{
+					RecommendedRateAdaptionMode: nada.RateAdaptionModeGradualUpdate,
+					AggregatedCongestionSignal:  123 * time.Millisecond,
+					ReceivingRate:               1_234_000,
+					ReceivingPacketRate:         25,
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			raw, err := c.pkt.Marshal()
+			if err != nil {
+				t.Fatalf("Marshal() = %v", err)
+			}
+
+			var got FeedbackPacket
+			if err := got.Unmarshal(raw); err != nil {
+				t.Fatalf("Unmarshal() = %v", err)
+			}
+
+			if got.SenderSSRC != c.pkt.SenderSSRC || got.MediaSSRC != c.pkt.MediaSSRC {
+				t.Errorf("SSRCs = %v/%v, want %v/%v", got.SenderSSRC, got.MediaSSRC, c.pkt.SenderSSRC, c.pkt.MediaSSRC)
+			}
+			if got.Report.RecommendedRateAdaptionMode != c.pkt.Report.RecommendedRateAdaptionMode {
+				t.Errorf("RecommendedRateAdaptionMode = %v, want %v", got.Report.RecommendedRateAdaptionMode, c.pkt.Report.RecommendedRateAdaptionMode)
+			}
+			if got.Report.ReceivingRate != c.pkt.Report.ReceivingRate {
+				t.Errorf("ReceivingRate = %v, want %v", got.Report.ReceivingRate, c.pkt.Report.ReceivingRate)
+			}
+			if got.Report.ReceivingPacketRate != c.pkt.Report.ReceivingPacketRate {
+				t.Errorf("ReceivingPacketRate = %v, want %v", got.Report.ReceivingPacketRate, c.pkt.Report.ReceivingPacketRate)
+			}
+			if got.Report.AggregatedCongestionSignal/time.Microsecond != c.pkt.Report.AggregatedCongestionSignal/time.Microsecond {
+				t.Errorf("AggregatedCongestionSignal = %v, want %v", got.Report.AggregatedCongestionSignal, c.pkt.Report.AggregatedCongestionSignal)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Package nadainterceptor adapts the nada package to pion/interceptor so
+// that a NADA congestion controller can be attached to a
+// webrtc.PeerConnection, following the pattern used by
+// github.com/pion/interceptor/pkg/cc.
+package nadainterceptor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/muxable/nada"
+	"github.com/pion/interceptor"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+)
+
+// BandwidthEstimator mirrors pion/interceptor/pkg/cc.BandwidthEstimator so
+// that encoders written against that interface can drive off of NADA
+// without change.
+type BandwidthEstimator interface {
+	GetTargetBitrate() int
+	OnTargetBitrateChange(f func(bitrate int))
+}
+
+// Option configures an Interceptor created by NewInterceptor.
+type Option func(*Interceptor) error
+
+// Config sets the nada.Config used for every Receiver and Sender created by
+// the interceptor. The default is nada.DefaultConfig().
+func Config(config nada.Config) Option {
+	return func(i *Interceptor) error {
+		i.config = config
+		return nil
+	}
+}
+
+// FeedbackInterval sets how often a FeedbackPacket is sent for each
+// receiving stream. The default is 100ms, matching RFC 8698's recommended
+// feedback interval.
+func FeedbackInterval(d time.Duration) Option {
+	return func(i *Interceptor) error {
+		i.feedbackInterval = d
+		return nil
+	}
+}
+
+// Factory creates Interceptors sharing a common configuration, for use with
+// a pion interceptor.Registry.
+type Factory struct {
+	opts []Option
+}
+
+// NewInterceptor returns a Factory that builds NADA Interceptors, following
+// the factory pattern used throughout pion/interceptor.
+func NewInterceptor(opts ...Option) (*Factory, error) {
+	return &Factory{opts: opts}, nil
+}
+
+// NewInterceptor implements interceptor.Factory.
+func (f *Factory) NewInterceptor(_ string) (interceptor.Interceptor, error) {
+	i := &Interceptor{
+		config:           nada.DefaultConfig(),
+		feedbackInterval: 100 * time.Millisecond,
+		receivers:        map[uint32]*streamReceiver{},
+		close:            make(chan struct{}),
+	}
+	for _, opt := range f.opts {
+		if err := opt(i); err != nil {
+			return nil, err
+		}
+	}
+	return i, nil
+}
+
+// streamReceiver tracks the NADA receiver state for one remote SSRC.
+type streamReceiver struct {
+	receiver *nada.Receiver
+}
+
+// Interceptor wraps a nada.Receiver (for streams received from a remote
+// peer) and a nada.Sender (for feedback received about streams sent to a
+// remote peer) behind the interceptor.Interceptor interface.
+type Interceptor struct {
+	interceptor.NoOp
+
+	lock   sync.Mutex
+	config nada.Config
+
+	feedbackInterval time.Duration
+
+	receivers map[uint32]*streamReceiver // keyed by media SSRC
+	localSSRC uint32                     // SSRC of the local stream, used as FeedbackPacket.SenderSSRC
+
+	sender                *nada.Sender
+	onTargetBitrateChange func(int)
+
+	close chan struct{}
+}
+
+// BindLocalStream records the local stream's SSRC so that it can be reported
+// as SenderSSRC on outgoing FeedbackPackets; it otherwise leaves the writer
+// untouched.
+func (i *Interceptor) BindLocalStream(streamInfo *interceptor.StreamInfo, writer interceptor.RTPWriter) interceptor.RTPWriter {
+	i.lock.Lock()
+	i.localSSRC = streamInfo.SSRC
+	i.lock.Unlock()
+	return writer
+}
+
+// BindRemoteStream sets up abs-send-time-based congestion feedback for an
+// incoming RTP stream: every packet is fed into a nada.Receiver, and a
+// FeedbackPacket is emitted on the bound RTCP writer every
+// FeedbackInterval.
+func (i *Interceptor) BindRemoteStream(streamInfo *interceptor.StreamInfo, reader interceptor.RTPReader) interceptor.RTPReader {
+	extensionID := rtpExtensionID(streamInfo, absSendTimeURI)
+
+	i.lock.Lock()
+	sr := &streamReceiver{receiver: nada.NewReceiver(timeNow(), i.config)}
+	i.receivers[streamInfo.SSRC] = sr
+	i.lock.Unlock()
+
+	return interceptor.RTPReaderFunc(func(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+		n, attr, err := reader.Read(b, a)
+		if err != nil {
+			return n, attr, err
+		}
+
+		pkt := &rtp.Packet{}
+		if err := pkt.Unmarshal(b[:n]); err != nil {
+			return n, attr, nil
+		}
+
+		sent, ok := absSendTime(pkt, extensionID)
+		if !ok {
+			return n, attr, nil
+		}
+
+		ecn, _ := attr.Get(ecnAttributesKey).(nada.ECN)
+
+		_ = sr.receiver.OnReceiveMediaPacket(timeNow(), sent, pkt.SequenceNumber, ecn, nada.Bits(len(b[:n])*8))
+
+		return n, attr, nil
+	})
+}
+
+// UnbindRemoteStream stops tracking a remote stream.
+func (i *Interceptor) UnbindRemoteStream(streamInfo *interceptor.StreamInfo) {
+	i.lock.Lock()
+	delete(i.receivers, streamInfo.SSRC)
+	i.lock.Unlock()
+}
+
+// BindRTCPWriter periodically emits a FeedbackPacket for every bound
+// remote stream.
+func (i *Interceptor) BindRTCPWriter(writer interceptor.RTCPWriter) interceptor.RTCPWriter {
+	go i.loop(writer)
+	return writer
+}
+
+func (i *Interceptor) loop(writer interceptor.RTCPWriter) {
+	ticker := time.NewTicker(i.feedbackInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-i.close:
+			return
+		case <-ticker.C:
+			i.lock.Lock()
+			reports := make([]rtcp.Packet, 0, len(i.receivers))
+			for ssrc, sr := range i.receivers {
+				reports = append(reports, &FeedbackPacket{
+					SenderSSRC: i.localSSRC,
+					MediaSSRC:  ssrc,
+					Report:     *sr.receiver.BuildFeedbackReport(),
+				})
+			}
+			i.lock.Unlock()
+
+			if len(reports) > 0 {
+				_, _ = writer.Write(reports, interceptor.Attributes{})
+			}
+		}
+	}
+}
+
+// BindRTCPReader parses incoming FeedbackPackets and updates the
+// Interceptor's nada.Sender, invoking any OnTargetBitrateChange callback
+// with the new target.
+func (i *Interceptor) BindRTCPReader(reader interceptor.RTCPReader) interceptor.RTCPReader {
+	return interceptor.RTCPReaderFunc(func(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+		n, attr, err := reader.Read(b, a)
+		if err != nil {
+			return n, attr, err
+		}
+
+		for _, fb := range extractFeedbackPackets(b[:n]) {
+			i.lock.Lock()
+			if i.sender == nil {
+				i.sender = nada.NewSender(timeNow(), i.config)
+			}
+			i.sender.OnReceiveFeedbackReport(timeNow(), &fb.Report)
+			target := i.sender.TargetRate()
+			cb := i.onTargetBitrateChange
+			i.lock.Unlock()
+
+			if cb != nil {
+				cb(int(target))
+			}
+		}
+
+		return n, attr, nil
+	})
+}
+
+// GetTargetBitrate implements BandwidthEstimator.
+func (i *Interceptor) GetTargetBitrate() int {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.sender == nil {
+		return int(i.config.RMIN)
+	}
+	return int(i.sender.TargetRate())
+}
+
+// OnTargetBitrateChange implements BandwidthEstimator.
+func (i *Interceptor) OnTargetBitrateChange(f func(bitrate int)) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.onTargetBitrateChange = f
+}
+
+// Close stops the feedback loop.
+func (i *Interceptor) Close() error {
+	select {
+	case <-i.close:
+	default:
+		close(i.close)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package nadainterceptor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+)
+
+type chanRTPReader struct {
+	pkts chan []byte
+}
+
+func (r *chanRTPReader) Read(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+	pkt := <-r.pkts
+	return copy(b, pkt), a, nil
+}
+
+type chanRTCPWriter struct {
+	out chan rtcp.Packet
+}
+
+func (w *chanRTCPWriter) Write(pkts []rtcp.Packet, _ interceptor.Attributes) (int, error) {
+	for _, p := range pkts {
+		w.out <- p
+	}
+	return 0, nil
+}
+
+type chanRTCPReader struct {
+	in chan []byte
+}
+
+func (r *chanRTCPReader) Read(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+	pkt := <-r.in
+	return copy(b, pkt), a, nil
+}
+
+// lossyDelayingPipe feeds RTP packets with an abs-send-time extension into
+// dst, dropping every dropEvery-th packet and applying jitter to the rest,
+// simulating a congested network path. It runs as a goroutine, so failures
+// are reported with Errorf rather than Fatalf, which is unsafe to call
+// outside the test's own goroutine.
+func lossyDelayingPipe(t *testing.T, dst chan<- []byte, extensionID uint8, n int, dropEvery int) {
+	t.Helper()
+	for seq := 0; seq < n; seq++ {
+		if dropEvery > 0 && seq%dropEvery == 0 {
+			continue
+		}
+
+		delay := time.Duration(seq%5) * time.Millisecond
+		sendTime := time.Now().Add(delay)
+
+		pkt := &rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				SequenceNumber: uint16(seq),
+				Timestamp:      uint32(seq) * 160,
+				SSRC:           1,
+			},
+			Payload: make([]byte, 100),
+		}
+		if err := pkt.SetExtension(extensionID, absSendTimeExtension(sendTime)); err != nil {
+			t.Errorf("SetExtension() = %v", err)
+			return
+		}
+
+		raw, err := pkt.Marshal()
+		if err != nil {
+			t.Errorf("Marshal() = %v", err)
+			return
+		}
+
+		dst <- raw
+	}
+}
+
+// absSendTimeExtension encodes t as a 24-bit abs-send-time value (seconds
+// modulo 64, in 6.18 fixed point), mirroring how a real sender would
+// timestamp outgoing packets.
+func absSendTimeExtension(t time.Time) []byte {
+	whole, frac := splitSeconds(t)
+	raw := uint32((float64(whole%64) + frac) * float64(absSendTimeEpoch))
+	return []byte{byte(raw >> 16), byte(raw >> 8), byte(raw)}
+}
+
+func TestInterceptorEndToEnd(t *testing.T) {
+	factory, err := NewInterceptor(FeedbackInterval(10 * time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewInterceptor() = %v", err)
+	}
+
+	receiveSide, err := factory.NewInterceptor("receive")
+	if err != nil {
+		t.Fatalf("NewInterceptor() = %v", err)
+	}
+	sendSide, err := factory.NewInterceptor("send")
+	if err != nil {
+		t.Fatalf("NewInterceptor() = %v", err)
+	}
+	defer receiveSide.Close()
+	defer sendSide.Close()
+
+	streamInfo := &interceptor.StreamInfo{
+		SSRC: 1,
+		RTPHeaderExtensions: []interceptor.RTPHeaderExtension{
+			{URI: absSendTimeURI, ID: 1},
+		},
+	}
+
+	rtpIn := make(chan []byte, 64)
+	reader := receiveSide.(interface {
+		BindRemoteStream(*interceptor.StreamInfo, interceptor.RTPReader) interceptor.RTPReader
+	}).BindRemoteStream(streamInfo, &chanRTPReader{pkts: rtpIn})
+
+	rtcpOut := make(chan rtcp.Packet, 64)
+	receiveSide.(interface {
+		BindRTCPWriter(interceptor.RTCPWriter) interceptor.RTCPWriter
+	}).BindRTCPWriter(&chanRTCPWriter{out: rtcpOut})
+
+	rtcpIn := make(chan []byte, 64)
+	rtcpReader := sendSide.(interface {
+		BindRTCPReader(interceptor.RTCPReader) interceptor.RTCPReader
+	}).BindRTCPReader(&chanRTCPReader{in: rtcpIn})
+
+	go lossyDelayingPipe(t, rtpIn, 1, 200, 20)
+
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			if _, _, err := reader.Read(buf, interceptor.Attributes{}); err != nil {
+				return
+			}
+		}
+	}()
+
+	var target int
+	deadline := time.After(2 * time.Second)
+	for i := 0; i < 5; i++ {
+		select {
+		case pkt := <-rtcpOut:
+			raw, err := pkt.Marshal()
+			if err != nil {
+				t.Fatalf("Marshal() = %v", err)
+			}
+			rtcpIn <- raw
+			if _, _, err := rtcpReader.Read(make([]byte, 1500), interceptor.Attributes{}); err != nil {
+				t.Fatalf("Read() = %v", err)
+			}
+			target = sendSide.(BandwidthEstimator).GetTargetBitrate()
+		case <-deadline:
+			t.Fatal("timed out waiting for feedback")
+		}
+	}
+
+	if target <= 0 {
+		t.Errorf("GetTargetBitrate() = %v, want > 0", target)
+	}
+}
@@ -0,0 +1,113 @@
+package nada
+
+import (
+	"time"
+)
+
+// packetEntry records the state of a single received packet within the
+// logging window used to compute instantaneous loss, marking, and receiving
+// rate estimates.
+type packetEntry struct {
+	arrival          time.Time
+	seq              uint16
+	ecn              ECN
+	size             Bits
+	hasQueueingDelay bool
+}
+
+// packetStream maintains a sliding window of recently received packets,
+// used by Receiver to derive instantaneous loss ratio, ECN marking ratio,
+// and receiving rate estimates.
+type packetStream struct {
+	window  time.Duration
+	packets []packetEntry
+	highSeq uint16
+	hasSeq  bool
+}
+
+func newPacketStream(window time.Duration) *packetStream {
+	return &packetStream{window: window}
+}
+
+// add records a newly received packet.
+func (s *packetStream) add(now time.Time, seq uint16, ecn ECN, size Bits, hasQueueingDelay bool) error {
+	if !s.hasSeq || seqGreater(seq, s.highSeq) {
+		s.highSeq = seq
+		s.hasSeq = true
+	}
+	s.packets = append(s.packets, packetEntry{
+		arrival:          now,
+		seq:              seq,
+		ecn:              ecn,
+		size:             size,
+		hasQueueingDelay: hasQueueingDelay,
+	})
+	return nil
+}
+
+// prune discards packets older than the logging window and returns the
+// instantaneous packet loss ratio, classic ECN-CE marking ratio, L4S
+// ECT(1) marking ratio, receiving bit rate, and receiving packet rate
+// computed over the remaining window, along with whether any packet in the
+// window observed queuing delay above the configured threshold.
+func (s *packetStream) prune(now time.Time) (lossRatio, markRatio, l4sMarkRatio float64, receivingRate BitsPerSecond, receivingPacketRate PacketsPerSecond, hasQueueingDelay bool) {
+	cutoff := now.Add(-s.window)
+	i := 0
+	for ; i < len(s.packets); i++ {
+		if s.packets[i].arrival.After(cutoff) {
+			break
+		}
+	}
+	s.packets = s.packets[i:]
+
+	if len(s.packets) == 0 {
+		return 0, 0, 0, 0, 0, false
+	}
+
+	var bits Bits
+	var marked, l4sMarked int
+	minSeq, maxSeq := s.packets[0].seq, s.packets[0].seq
+	for _, p := range s.packets {
+		bits += p.size
+		switch p.ecn {
+		case ECNCE:
+			marked++
+		case ECNECT1:
+			l4sMarked++
+		}
+		if p.hasQueueingDelay {
+			hasQueueingDelay = true
+		}
+		if seqGreater(minSeq, p.seq) {
+			minSeq = p.seq
+		}
+		if seqGreater(p.seq, maxSeq) {
+			maxSeq = p.seq
+		}
+	}
+
+	expected := int(maxSeq-minSeq) + 1
+	if expected < len(s.packets) {
+		expected = len(s.packets)
+	}
+	lost := expected - len(s.packets)
+
+	lossRatio = float64(lost) / float64(expected)
+	markRatio = float64(marked) / float64(len(s.packets))
+	l4sMarkRatio = float64(l4sMarked) / float64(len(s.packets))
+
+	span := now.Sub(s.packets[0].arrival)
+	if span <= 0 {
+		span = s.window
+	}
+	receivingRate = BitsPerSecond(float64(bits) / span.Seconds())
+	receivingPacketRate = PacketsPerSecond(float64(len(s.packets)) / span.Seconds())
+
+	return lossRatio, markRatio, l4sMarkRatio, receivingRate, receivingPacketRate, hasQueueingDelay
+}
+
+// seqGreater reports whether a is later than b in RTP sequence number space,
+// accounting for wraparound.
+func seqGreater(a, b uint16) bool {
+	return int16(a-b) > 0
+}
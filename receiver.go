@@ -11,7 +11,9 @@ type Receiver struct {
 	EstimatedQueuingDelay          time.Duration // d_queue
 	EstimatedPacketLossRatio       float64
 	EstimatedPacketECNMarkingRatio float64
+	EstimatedL4SMarkingRatio       float64
 	ReceivingRate                  BitsPerSecond
+	ReceivingPacketRate            PacketsPerSecond
 	LastTimestamp                  time.Time
 	CurrentTimestamp               time.Time
 	RecommendedRateAdaptionMode    RateAdaptionMode
@@ -25,6 +27,7 @@ func NewReceiver(now time.Time, config Config) *Receiver {
 		BaselineDelay:                  time.Duration(1<<63 - 1),
 		EstimatedPacketLossRatio:       0.0,
 		EstimatedPacketECNMarkingRatio: 0.0,
+		EstimatedL4SMarkingRatio:       0.0,
 		ReceivingRate:                  0.0,
 		LastTimestamp:                  now,
 		CurrentTimestamp:               now,
@@ -33,7 +36,7 @@ func NewReceiver(now time.Time, config Config) *Receiver {
 }
 
 // OnReceiveMediaPacket implements the media receive algorithm.
-func (r *Receiver) OnReceiveMediaPacket(now time.Time, sent time.Time, seq uint16, ecn bool, size Bits) error {
+func (r *Receiver) OnReceiveMediaPacket(now time.Time, sent time.Time, seq uint16, ecn ECN, size Bits) error {
 	// obtain current timestamp t_curr from system clock
 	r.CurrentTimestamp = now
 
@@ -55,7 +58,7 @@ func (r *Receiver) OnReceiveMediaPacket(now time.Time, sent time.Time, seq uint1
 		return err
 	}
 
-	p_loss_inst, p_mark_inst, r_recv_inst, hasQueueingDelay := r.packetStream.prune(now)
+	p_loss_inst, p_mark_inst, p_l4s_mark_inst, r_recv_inst, p_rate_inst, hasQueueingDelay := r.packetStream.prune(now)
 
 	// update packet loss ratio estimate p_loss
 	// r.config.α*p_loss_inst + (1-r.config.α)*r.EstimatedPacketLossRatio
@@ -65,11 +68,19 @@ func (r *Receiver) OnReceiveMediaPacket(now time.Time, sent time.Time, seq uint1
 	// r.config.α*p_mark_inst + (1-r.config.α)*r.EstimatedPacketECNMarkingRatio
 	r.EstimatedPacketECNMarkingRatio = r.config.α*(p_mark_inst-r.EstimatedPacketECNMarkingRatio) + r.EstimatedPacketECNMarkingRatio
 
+	// update L4S ECT(1) marking ratio estimate, analogous to p_mark above
+	r.EstimatedL4SMarkingRatio = r.config.α*(p_l4s_mark_inst-r.EstimatedL4SMarkingRatio) + r.EstimatedL4SMarkingRatio
+
 	// update measurement of receiving rate r_recv
 	r.ReceivingRate = r_recv_inst
 
-	// update recommended rate adaption mode.
-	if p_loss_inst == 0 && !hasQueueingDelay {
+	// update measurement of receiving packet rate
+	r.ReceivingPacketRate = p_rate_inst
+
+	// update recommended rate adaption mode. Accelerated Ramp-Up additionally
+	// requires a minimum observed packet rate, so a quiet audio-only or
+	// DTX-heavy stream isn't mistaken for one with spare capacity to probe.
+	if p_loss_inst == 0 && !hasQueueingDelay && r.ReceivingPacketRate >= r.config.MinPacketRateForRampUp {
 		r.RecommendedRateAdaptionMode = RateAdaptionModeAcceleratedRampUp
 	} else {
 		r.RecommendedRateAdaptionMode = RateAdaptionModeGradualUpdate
@@ -86,6 +97,7 @@ func (r *Receiver) BuildFeedbackReport() *FeedbackReport {
 	// calculate current aggregate congestion signal x_curr
 	aggregatedCongestionSignal := equivalentDelay +
 		scale(r.config.ReferenceDelayMarking, math.Pow(r.EstimatedPacketECNMarkingRatio/r.config.ReferencePacketMarkingRatio, 2)) +
+		scale(r.config.ReferenceDelayL4SMarking, math.Pow(r.EstimatedL4SMarkingRatio/r.config.ReferenceL4SMarkingRatio, 2)) +
 		scale(r.config.ReferenceDelayLoss, math.Pow(r.EstimatedPacketLossRatio/r.config.ReferencePacketLossRatio, 2))
 
 	// determine mode of rate adaptation for sender: rmode
@@ -99,6 +111,7 @@ func (r *Receiver) BuildFeedbackReport() *FeedbackReport {
 		RecommendedRateAdaptionMode: rmode,
 		AggregatedCongestionSignal:  aggregatedCongestionSignal,
 		ReceivingRate:               r.ReceivingRate,
+		ReceivingPacketRate:         r.ReceivingPacketRate,
 	}
 }
 
@@ -0,0 +1,72 @@
+package nada
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReceiverL4SMarkingContributesSmallerDelayPenalty(t *testing.T) {
+	newReceiver := func() *Receiver {
+		r := NewReceiver(time.Now(), DefaultConfig())
+		r.BaselineDelay = 0
+		return r
+	}
+
+	feed := func(r *Receiver, ecn ECN) *FeedbackReport {
+		now := time.Now()
+		for i := 0; i < 50; i++ {
+			now = now.Add(10 * time.Millisecond)
+			if err := r.OnReceiveMediaPacket(now, now, uint16(i), ecn, 1000); err != nil {
+				t.Fatalf("OnReceiveMediaPacket() = %v", err)
+			}
+		}
+		return r.BuildFeedbackReport()
+	}
+
+	ceReport := feed(newReceiver(), ECNCE)
+	l4sReport := feed(newReceiver(), ECNECT1)
+
+	if l4sReport.AggregatedCongestionSignal >= ceReport.AggregatedCongestionSignal {
+		t.Errorf("L4S AggregatedCongestionSignal = %v, want less than classic CE's %v", l4sReport.AggregatedCongestionSignal, ceReport.AggregatedCongestionSignal)
+	}
+}
+
+func TestReceiverTracksL4SMarkingRatioSeparately(t *testing.T) {
+	r := NewReceiver(time.Now(), DefaultConfig())
+	r.BaselineDelay = 0
+
+	now := time.Now()
+	for i := 0; i < 50; i++ {
+		now = now.Add(10 * time.Millisecond)
+		if err := r.OnReceiveMediaPacket(now, now, uint16(i), ECNECT1, 1000); err != nil {
+			t.Fatalf("OnReceiveMediaPacket() = %v", err)
+		}
+	}
+
+	if r.EstimatedL4SMarkingRatio == 0 {
+		t.Errorf("EstimatedL4SMarkingRatio = 0, want > 0")
+	}
+	if r.EstimatedPacketECNMarkingRatio != 0 {
+		t.Errorf("EstimatedPacketECNMarkingRatio = %v, want 0 (ECT(1) should not count as classic CE)", r.EstimatedPacketECNMarkingRatio)
+	}
+}
+
+func TestReceiverWithholdsRampUpBelowMinPacketRate(t *testing.T) {
+	config := DefaultConfig()
+	config.MinPacketRateForRampUp = 1000 // unreasonably high, to force the gate
+
+	r := NewReceiver(time.Now(), config)
+	r.BaselineDelay = 0
+
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		now = now.Add(20 * time.Millisecond)
+		if err := r.OnReceiveMediaPacket(now, now, uint16(i), ECNNotECT, 100); err != nil {
+			t.Fatalf("OnReceiveMediaPacket() = %v", err)
+		}
+	}
+
+	if r.RecommendedRateAdaptionMode != RateAdaptionModeGradualUpdate {
+		t.Errorf("RecommendedRateAdaptionMode = %v, want GradualUpdate when packet rate is below MinPacketRateForRampUp", r.RecommendedRateAdaptionMode)
+	}
+}
@@ -0,0 +1,154 @@
+package nada
+
+import (
+	"math"
+	"time"
+)
+
+// Sender implements the sender-side reference rate calculation described in
+// RFC 8698 Section 5. It consumes FeedbackReports produced by a peer
+// Receiver and maintains a reference rate r_ref that the caller should feed
+// to its encoder.
+type Sender struct {
+	config Config
+
+	r_ref  BitsPerSecond
+	x_prev time.Duration
+	t_last time.Time
+}
+
+// NewSender creates a Sender with its reference rate initialized to
+// config.RMIN, as recommended by RFC 8698 Section 5.
+func NewSender(now time.Time, config Config) *Sender {
+	return &Sender{
+		config: config,
+		r_ref:  config.RMIN,
+		t_last: now,
+	}
+}
+
+// TargetRate returns r_ref, the rate the caller should currently encode at.
+func (s *Sender) TargetRate() BitsPerSecond {
+	return s.r_ref
+}
+
+// OnReceiveFeedbackReport updates r_ref in response to a FeedbackReport,
+// using the rate adaption mode the receiver recommended.
+func (s *Sender) OnReceiveFeedbackReport(now time.Time, report *FeedbackReport) {
+	x_curr := report.AggregatedCongestionSignal
+	r_recv := report.ReceivingRate
+
+	switch report.RecommendedRateAdaptionMode {
+	case RateAdaptionModeAcceleratedRampUp:
+		s.rampUp(r_recv)
+	case RateAdaptionModeGradualUpdate:
+		if x_curr >= s.config.ReferenceDelayLoss {
+			// Heavy loss: the loss term alone already accounts for the
+			// reference congestion level, so the iterative formula below
+			// would converge too slowly. Back off directly to the
+			// measured receiving rate instead.
+			s.reduceOnHeavyLoss(r_recv)
+		} else {
+			s.gradualUpdate(now, x_curr, r_recv, report.ReceivingPacketRate)
+		}
+	}
+
+	s.x_prev = x_curr
+	s.t_last = now
+}
+
+// rampUp implements Accelerated Ramp-Up (RFC 8698 Section 5.1):
+//
+//	                          1
+//	gamma = min(GAMMA_MAX, -------------------------- )
+//	                       1 + max(0, log(r_recv/r_ref))
+//
+//	r_ref = (1+gamma) * r_recv
+//
+// r_ref is used in place of RTP_bitrate as the estimate of the rate
+// currently being sent. The full RFC formula scales the bound by
+// QBOUND/RTT, but Sender does not track round-trip time, so that term is
+// omitted rather than hardcoded to a value that could never reflect the
+// actual path RTT; gamma is bounded by GAMMA_MAX alone until RTT tracking
+// is added.
+func (s *Sender) rampUp(r_recv BitsPerSecond) {
+	if r_recv <= 0 {
+		return
+	}
+
+	logRatio := math.Log(float64(r_recv) / float64(s.r_ref))
+	gamma := 1 / (1 + math.Max(0, logRatio))
+	gamma = math.Min(s.config.GAMMA_MAX, gamma)
+
+	s.setRate(BitsPerSecond((1 + gamma) * float64(r_recv)))
+}
+
+// gradualUpdate implements Gradual Rate Update (RFC 8698 Section 5.2):
+//
+//	x_offset = x_curr - PRIO*XREF*RMAX/r_ref
+//	x_diff   = x_curr - x_prev
+//	r_ref   += -KAPPA*(delta/TAU_O)*x_offset*r_ref/XREF
+//	          -KAPPA*ETA*x_diff*r_ref/XREF
+//
+// The ETA term above reacts to the rate of change of the congestion
+// signal, which is noisier for small-packet flows (e.g. audio-only or
+// DTX-heavy streams) whose packets arrive in a bursty, pacer-starved
+// pattern unrelated to actual congestion. When packetRate indicates such a
+// flow, that term is scaled down so Gradual Update doesn't overreact to it.
+func (s *Sender) gradualUpdate(now time.Time, x_curr time.Duration, r_recv BitsPerSecond, packetRate PacketsPerSecond) {
+	if s.r_ref <= 0 {
+		return
+	}
+	delta := now.Sub(s.t_last)
+
+	x_offset := float64(x_curr) - s.config.PRIO*float64(s.config.XREF)*float64(s.config.RMAX)/float64(s.r_ref)
+	x_diff := float64(x_curr - s.x_prev)
+
+	eta := s.config.ETA
+	if isPacerStarved(r_recv, packetRate) {
+		eta *= pacerStarvedETAScale
+	}
+
+	step := -s.config.KAPPA*(delta.Seconds()/s.config.TAU_O.Seconds())*x_offset*float64(s.r_ref)/float64(s.config.XREF) -
+		s.config.KAPPA*eta*x_diff*float64(s.r_ref)/float64(s.config.XREF)
+
+	s.setRate(s.r_ref + BitsPerSecond(step))
+}
+
+// pacerStarvedAvgPacketSize is the average packet size, in bits, below
+// which a stream is considered pacer-starved rather than congested: lots
+// of small packets (audio, DTX, RTCP-heavy traffic) rather than a media
+// bitstream being paced out onto a congested link.
+const pacerStarvedAvgPacketSize = 1600 // 200 bytes
+
+// pacerStarvedETAScale damps the ETA term of Gradual Update for
+// pacer-starved flows detected by isPacerStarved.
+const pacerStarvedETAScale = 0.25
+
+// isPacerStarved reports whether r_recv and packetRate describe a flow
+// sending many small packets rather than a rate-limited media bitstream.
+func isPacerStarved(r_recv BitsPerSecond, packetRate PacketsPerSecond) bool {
+	if packetRate <= 0 {
+		return false
+	}
+	avgPacketSize := float64(r_recv) / float64(packetRate)
+	return avgPacketSize < pacerStarvedAvgPacketSize
+}
+
+// reduceOnHeavyLoss backs the reference rate off to the measured receiving
+// rate when the loss-contributed portion of the congestion signal already
+// dominates, rather than letting the gradual update formula slowly converge.
+func (s *Sender) reduceOnHeavyLoss(r_recv BitsPerSecond) {
+	s.setRate(r_recv)
+}
+
+// setRate clamps and stores a newly computed reference rate.
+func (s *Sender) setRate(r BitsPerSecond) {
+	if r < s.config.RMIN {
+		r = s.config.RMIN
+	}
+	if r > s.config.RMAX {
+		r = s.config.RMAX
+	}
+	s.r_ref = r
+}
@@ -0,0 +1,130 @@
+package nada
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSenderRampUp(t *testing.T) {
+	cases := []struct {
+		name   string
+		r_recv BitsPerSecond
+		want   func(got BitsPerSecond) bool
+	}{
+		{
+			name:   "increases toward receiving rate",
+			r_recv: 300_000,
+			want: func(got BitsPerSecond) bool {
+				return got > 150_000 && got <= 300_000*1.5
+			},
+		},
+		{
+			name:   "clamps to RMAX",
+			r_recv: 10_000_000,
+			want: func(got BitsPerSecond) bool {
+				return got == DefaultConfig().RMAX
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			now := time.Now()
+			s := NewSender(now, DefaultConfig())
+			s.OnReceiveFeedbackReport(now, &FeedbackReport{
+				RecommendedRateAdaptionMode: RateAdaptionModeAcceleratedRampUp,
+				ReceivingRate:               c.r_recv,
+			})
+			if !c.want(s.TargetRate()) {
+				t.Errorf("TargetRate() = %v, did not satisfy expectation", s.TargetRate())
+			}
+		})
+	}
+}
+
+func TestSenderGradualUpdate(t *testing.T) {
+	config := DefaultConfig()
+	now := time.Now()
+	s := NewSender(now, config)
+	s.r_ref = 500_000
+
+	now = now.Add(config.TAU_O)
+	s.OnReceiveFeedbackReport(now, &FeedbackReport{
+		RecommendedRateAdaptionMode: RateAdaptionModeGradualUpdate,
+		AggregatedCongestionSignal:  config.XREF,
+		ReceivingRate:               500_000,
+	})
+
+	if s.TargetRate() < config.RMIN || s.TargetRate() > config.RMAX {
+		t.Fatalf("TargetRate() = %v, out of [RMIN, RMAX]", s.TargetRate())
+	}
+}
+
+func TestSenderRateReductionOnHeavyLoss(t *testing.T) {
+	config := DefaultConfig()
+	now := time.Now()
+	s := NewSender(now, config)
+	s.r_ref = 1_000_000
+
+	now = now.Add(config.TAU_O)
+	s.OnReceiveFeedbackReport(now, &FeedbackReport{
+		RecommendedRateAdaptionMode: RateAdaptionModeGradualUpdate,
+		AggregatedCongestionSignal:  config.ReferenceDelayLoss * 2,
+		ReceivingRate:               400_000,
+	})
+
+	if s.TargetRate() != 400_000 {
+		t.Errorf("TargetRate() = %v, want backoff to receiving rate 400000", s.TargetRate())
+	}
+}
+
+func TestSenderDampensGradualUpdateForPacerStarvedFlows(t *testing.T) {
+	config := DefaultConfig()
+
+	run := func(packetRate PacketsPerSecond) BitsPerSecond {
+		now := time.Now()
+		s := NewSender(now, config)
+		s.r_ref = 500_000
+		s.x_prev = config.XREF / 2
+
+		now = now.Add(config.TAU_O)
+		s.OnReceiveFeedbackReport(now, &FeedbackReport{
+			RecommendedRateAdaptionMode: RateAdaptionModeGradualUpdate,
+			AggregatedCongestionSignal:  config.XREF * 3, // a large x_diff, to exercise the ETA term
+			ReceivingRate:               500_000,
+			ReceivingPacketRate:         packetRate,
+		})
+		return s.TargetRate()
+	}
+
+	// 500kbps over 2500 packets/sec is a 200-bit average packet size: a
+	// pacer-starved, small-packet flow.
+	starved := run(2500)
+	// 500kbps over 50 packets/sec is a normal media packet size.
+	healthy := run(50)
+
+	starvedDelta := math.Abs(float64(starved - 500_000))
+	healthyDelta := math.Abs(float64(healthy - 500_000))
+
+	if starvedDelta >= healthyDelta {
+		t.Errorf("pacer-starved rate change %v, want smaller than healthy-flow rate change %v", starvedDelta, healthyDelta)
+	}
+}
+
+func TestSenderClampsToConfiguredBounds(t *testing.T) {
+	config := DefaultConfig()
+	config.RMIN = 200_000
+	config.RMAX = 800_000
+
+	now := time.Now()
+	s := NewSender(now, config)
+
+	s.OnReceiveFeedbackReport(now, &FeedbackReport{
+		RecommendedRateAdaptionMode: RateAdaptionModeAcceleratedRampUp,
+		ReceivingRate:               50_000,
+	})
+	if s.TargetRate() < config.RMIN {
+		t.Errorf("TargetRate() = %v, below RMIN %v", s.TargetRate(), config.RMIN)
+	}
+}
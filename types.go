@@ -0,0 +1,44 @@
+package nada
+
+// Bits is a quantity of data, expressed in bits.
+type Bits float64
+
+// BitsPerSecond is a data rate, expressed in bits per second.
+type BitsPerSecond float64
+
+// PacketsPerSecond is a packet rate, expressed in packets per second.
+type PacketsPerSecond float64
+
+// RateAdaptionMode is the rate adaption mode recommended by a Receiver, as
+// described in RFC 8698 Section 5.
+type RateAdaptionMode int
+
+const (
+	// RateAdaptionModeAcceleratedRampUp is recommended when there is no
+	// sign of congestion (no loss, no queuing delay) and the sender should
+	// probe for available bandwidth quickly.
+	RateAdaptionModeAcceleratedRampUp RateAdaptionMode = iota
+	// RateAdaptionModeGradualUpdate is recommended once congestion signals
+	// are present and the sender should converge on a fair share slowly.
+	RateAdaptionModeGradualUpdate
+)
+
+// ECN is the two-bit ECN codepoint carried in the IP header, as defined by
+// RFC 3168 Section 5. Receiver tracks classic ECN-CE and L4S-style ECT(1)
+// marking separately, per RFC 9331.
+type ECN uint8
+
+const (
+	// ECNNotECT marks a packet as not using ECN, codepoint "00".
+	ECNNotECT ECN = 0
+	// ECNECT1 marks a packet as ECN Capable Transport, codepoint "01".
+	// RFC 9331 repurposes this codepoint for L4S, where each mark is a
+	// fine-grained congestion signal rather than an indication of a full
+	// queue.
+	ECNECT1 ECN = 1
+	// ECNECT0 marks a packet as ECN Capable Transport, codepoint "10".
+	ECNECT0 ECN = 2
+	// ECNCE marks a packet as having experienced classic congestion,
+	// codepoint "11".
+	ECNCE ECN = 3
+)